@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// =============================================================================
+// OUTBOUND HTTP CLIENT INSTRUMENTATION
+// =============================================================================
+
+// SentryRoundTripper wraps an http.RoundTripper and records each outbound
+// request as a child of the span found in the request context.
+// Requests made outside of a Sentry transaction are forwarded unchanged so
+// that no orphan spans are created.
+type SentryRoundTripper struct {
+	next   http.RoundTripper
+	sentry *SentryService
+	tags   map[string]string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *SentryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	parentSpan := sentry.SpanFromContext(request.Context())
+	if parentSpan == nil {
+		return rt.next.RoundTrip(request)
+	}
+
+	span := parentSpan.StartChild(
+		"http.client",
+		sentry.WithDescription(fmt.Sprintf("%s %s", request.Method, request.URL.Redacted())),
+	)
+	defer span.Finish()
+
+	for key, value := range rt.tags {
+		span.SetTag(key, value)
+	}
+	span.SetData("http.request.method", request.Method)
+
+	// Let the downstream service continue this trace.
+	rt.sentry.InjectTraceHeaders(span, request.Header)
+
+	response, err := rt.next.RoundTrip(request)
+	if err != nil {
+		span.Status = sentry.SpanStatusInternalError
+		return response, err
+	}
+
+	span.SetData("http.response.status_code", response.StatusCode)
+	span.SetData("http.response_content_length", response.ContentLength)
+	if response.StatusCode >= http.StatusInternalServerError {
+		span.Status = sentry.SpanStatusInternalError
+	} else {
+		span.Status = sentry.SpanStatusOK
+	}
+
+	return response, nil
+}
+
+// NewInstrumentedHTTPClient returns an *http.Client whose transport reports
+// every outbound request as a child span, tagged with the given tags. Use
+// the client from within a Sentry transaction (e.g. inside WithTransaction
+// or WithSpan) so downstream calls are attached to the right trace.
+func (s *SentryService) NewInstrumentedHTTPClient(tags map[string]string) *http.Client {
+	transport := http.DefaultTransport
+	return &http.Client{
+		Transport: &SentryRoundTripper{
+			next:   transport,
+			sentry: s,
+			tags:   tags,
+		},
+	}
+}
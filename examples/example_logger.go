@@ -0,0 +1,120 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// =============================================================================
+// DUAL STDOUT/SENTRY LOGGER
+// =============================================================================
+
+// capturingWriter intercepts bytes written through it, forwards them
+// unchanged to next, and reports the captured message to Sentry at level.
+type capturingWriter struct {
+	next   *log.Logger
+	logger *SentryLogger
+	level  sentry.Level
+}
+
+func (w *capturingWriter) Write(p []byte) (int, error) {
+	message := strings.TrimRight(string(p), "\n")
+	w.logger.capture(w.level, message)
+	w.next.Print(message)
+	return len(p), nil
+}
+
+// SentryLogger wraps a *log.Logger and mirrors every message printed
+// through its Debugf/Infof/Warnf/Errorf/Fatalf methods to Bugsink at a
+// matching severity, so those call sites stay visible locally and in
+// Bugsink without extra CaptureException calls. It is not itself a
+// *log.Logger and has no Print, Printf or Println methods.
+type SentryLogger struct {
+	sentry  *SentryService
+	debug   *log.Logger
+	info    *log.Logger
+	warn    *log.Logger
+	err     *log.Logger
+	fatal   *log.Logger
+	request *http.Request
+}
+
+// NewSentryLogger wraps out (typically os.Stdout/os.Stderr via log.New)
+// with a SentryLogger that captures everything printed through it.
+func (s *SentryService) NewSentryLogger(out *log.Logger) *SentryLogger {
+	logger := &SentryLogger{sentry: s}
+	logger.debug = log.New(&capturingWriter{next: out, logger: logger, level: sentry.LevelDebug}, "", 0)
+	logger.info = log.New(&capturingWriter{next: out, logger: logger, level: sentry.LevelInfo}, "", 0)
+	logger.warn = log.New(&capturingWriter{next: out, logger: logger, level: sentry.LevelWarning}, "", 0)
+	logger.err = log.New(&capturingWriter{next: out, logger: logger, level: sentry.LevelError}, "", 0)
+	logger.fatal = log.New(&capturingWriter{next: out, logger: logger, level: sentry.LevelFatal}, "", 0)
+	return logger
+}
+
+// rebind returns a *log.Logger backed by a new capturingWriter with the
+// same destination and level as logger's, but reporting captures against
+// owner instead of logger's original owner.
+func rebind(logger *log.Logger, owner *SentryLogger) *log.Logger {
+	cw := logger.Writer().(*capturingWriter)
+	return log.New(&capturingWriter{next: cw.next, logger: owner, level: cw.level}, "", 0)
+}
+
+// Request returns a copy of the logger with r attached, so that any event
+// captured through the copy afterwards is enriched with that request's
+// context. Chain it at the top of an HTTP handler, e.g.
+// `logger.Request(r).Errorf(...)`. It returns a copy rather than mutating
+// the receiver so that concurrent requests sharing the same base logger
+// don't race on which request is attached.
+func (l *SentryLogger) Request(r *http.Request) *SentryLogger {
+	requestLogger := &SentryLogger{sentry: l.sentry, request: r}
+	requestLogger.debug = rebind(l.debug, requestLogger)
+	requestLogger.info = rebind(l.info, requestLogger)
+	requestLogger.warn = rebind(l.warn, requestLogger)
+	requestLogger.err = rebind(l.err, requestLogger)
+	requestLogger.fatal = rebind(l.fatal, requestLogger)
+	return requestLogger
+}
+
+// Debugf logs a debug-level message.
+func (l *SentryLogger) Debugf(format string, args ...interface{}) {
+	l.debug.Printf(format, args...)
+}
+
+// Infof logs an info-level message.
+func (l *SentryLogger) Infof(format string, args ...interface{}) {
+	l.info.Printf(format, args...)
+}
+
+// Warnf logs a warning-level message.
+func (l *SentryLogger) Warnf(format string, args ...interface{}) {
+	l.warn.Printf(format, args...)
+}
+
+// Errorf logs an error-level message.
+func (l *SentryLogger) Errorf(format string, args ...interface{}) {
+	l.err.Printf(format, args...)
+}
+
+// Fatalf logs a fatal-level message, captures it in Sentry, flushes the
+// pending event, and terminates the process via os.Exit(1), mirroring
+// log.Fatalf.
+func (l *SentryLogger) Fatalf(format string, args ...interface{}) {
+	l.fatal.Printf(format, args...)
+	l.sentry.Flush(5 * time.Second)
+	os.Exit(1)
+}
+
+func (l *SentryLogger) capture(level sentry.Level, message string) {
+	sentry.WithScope(func(scope *sentry.Scope) {
+		scope.SetLevel(level)
+		if l.request != nil {
+			scope.SetRequest(l.request)
+		}
+		sentry.CaptureMessage(message)
+	})
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/sirupsen/logrus"
+)
+
+// =============================================================================
+// LOG FORWARDING (logrus / slog -> Sentry)
+// =============================================================================
+
+// SentryLogHook forwards logrus (and slog) log lines to Bugsink so callers
+// don't need to sprinkle CaptureException calls next to every log site.
+// Entries at one of the configured reportLevels are sent as events;
+// everything else is kept around as a breadcrumb in case a later event
+// needs the context.
+type SentryLogHook struct {
+	sentry       *SentryService
+	reportLevels []logrus.Level
+}
+
+// NewSentryLogHook creates a hook that reports ErrorLevel, FatalLevel and
+// PanicLevel entries as Sentry events by default. Pass custom levels to
+// change what gets reported as an event; lower levels are always kept as
+// breadcrumbs.
+func (s *SentryService) NewSentryLogHook(levels ...logrus.Level) *SentryLogHook {
+	if len(levels) == 0 {
+		levels = []logrus.Level{logrus.ErrorLevel, logrus.FatalLevel, logrus.PanicLevel}
+	}
+	return &SentryLogHook{sentry: s, reportLevels: levels}
+}
+
+// Levels implements logrus.Hook. It returns every level so that entries
+// below the reporting threshold still reach Fire and get collected as
+// breadcrumbs.
+func (h *SentryLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (h *SentryLogHook) Fire(entry *logrus.Entry) error {
+	if !h.shouldReport(entry.Level) {
+		sentry.AddBreadcrumb(&sentry.Breadcrumb{
+			Message:   entry.Message,
+			Category:  "log",
+			Level:     logrusLevelToSentry(entry.Level),
+			Data:      fieldsToMap(entry.Data),
+			Timestamp: entry.Time,
+		})
+		return nil
+	}
+
+	sentry.WithScope(func(scope *sentry.Scope) {
+		scope.SetLevel(logrusLevelToSentry(entry.Level))
+
+		var originalErr error
+		for key, value := range entry.Data {
+			if err, ok := value.(error); ok {
+				originalErr = err
+				continue
+			}
+			scope.SetExtra(key, value)
+		}
+
+		if entry.Context != nil {
+			if request, ok := entry.Context.Value(sentry.RequestContextKey).(*http.Request); ok {
+				scope.SetRequest(request)
+			}
+		}
+
+		if originalErr != nil {
+			// CaptureException populates hint.OriginalException with
+			// originalErr, so beforeSendHandler's errors.As check still
+			// applies to log-originated events.
+			sentry.CaptureException(originalErr)
+			return
+		}
+
+		sentry.CaptureMessage(entry.Message)
+	})
+
+	return nil
+}
+
+func (h *SentryLogHook) shouldReport(level logrus.Level) bool {
+	for _, reportable := range h.reportLevels {
+		if reportable == level {
+			return true
+		}
+	}
+	return false
+}
+
+// Flush drains any pending log-originated events before shutdown.
+func (h *SentryLogHook) Flush(timeout time.Duration) bool {
+	return sentry.Flush(timeout)
+}
+
+func logrusLevelToSentry(level logrus.Level) sentry.Level {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return sentry.LevelFatal
+	case logrus.ErrorLevel:
+		return sentry.LevelError
+	case logrus.WarnLevel:
+		return sentry.LevelWarning
+	case logrus.DebugLevel, logrus.TraceLevel:
+		return sentry.LevelDebug
+	default:
+		return sentry.LevelInfo
+	}
+}
+
+func fieldsToMap(fields logrus.Fields) map[string]interface{} {
+	data := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		data[key] = value
+	}
+	return data
+}
+
+// SentrySlogHandler is the slog.Handler equivalent of SentryLogHook, for
+// services that use log/slog instead of logrus.
+type SentrySlogHandler struct {
+	next   slog.Handler
+	sentry *SentryService
+	levels []slog.Level
+}
+
+// NewSentrySlogHandler wraps next, forwarding records at or above
+// LevelError (or, when levels are given, at or above the lowest of them)
+// to Bugsink while leaving everything else untouched.
+func (s *SentryService) NewSentrySlogHandler(next slog.Handler, levels ...slog.Level) *SentrySlogHandler {
+	if len(levels) == 0 {
+		levels = []slog.Level{slog.LevelError}
+	}
+	return &SentrySlogHandler{next: next, sentry: s, levels: levels}
+}
+
+// Enabled implements slog.Handler.
+func (h *SentrySlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *SentrySlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.shouldReport(record.Level) {
+		sentry.WithScope(func(scope *sentry.Scope) {
+			scope.SetLevel(slogLevelToSentry(record.Level))
+
+			var originalErr error
+			record.Attrs(func(attr slog.Attr) bool {
+				if err, ok := attr.Value.Any().(error); ok {
+					originalErr = err
+					return false
+				}
+				scope.SetExtra(attr.Key, attr.Value.Any())
+				return true
+			})
+
+			if originalErr != nil {
+				// CaptureException populates hint.OriginalException with
+				// originalErr, so beforeSendHandler's errors.As check still
+				// applies to log-originated events.
+				sentry.CaptureException(originalErr)
+				return
+			}
+
+			sentry.CaptureMessage(record.Message)
+		})
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *SentrySlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SentrySlogHandler{next: h.next.WithAttrs(attrs), sentry: h.sentry, levels: h.levels}
+}
+
+// WithGroup implements slog.Handler.
+func (h *SentrySlogHandler) WithGroup(name string) slog.Handler {
+	return &SentrySlogHandler{next: h.next.WithGroup(name), sentry: h.sentry, levels: h.levels}
+}
+
+func (h *SentrySlogHandler) shouldReport(level slog.Level) bool {
+	for _, reportable := range h.levels {
+		if level >= reportable {
+			return true
+		}
+	}
+	return false
+}
+
+func slogLevelToSentry(level slog.Level) sentry.Level {
+	switch {
+	case level >= slog.LevelError:
+		return sentry.LevelError
+	case level >= slog.LevelWarn:
+		return sentry.LevelWarning
+	case level >= slog.LevelInfo:
+		return sentry.LevelInfo
+	default:
+		return sentry.LevelDebug
+	}
+}
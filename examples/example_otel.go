@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+
+	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// =============================================================================
+// OPENTELEMETRY BRIDGE
+// =============================================================================
+
+// DualSpan mirrors attributes onto an OTel span and a Sentry span at the
+// same time, for services that run Instrumenter: "otel" (see Init) and
+// still want events grouped under the matching trace in Bugsink.
+type DualSpan struct {
+	otel   trace.Span
+	sentry *sentry.Span
+}
+
+// SetData sets key as an attribute on the OTel span and as data on the
+// Sentry span.
+func (d *DualSpan) SetData(key string, value string) {
+	if d.otel != nil {
+		d.otel.SetAttributes(attribute.String(key, value))
+	}
+	if d.sentry != nil {
+		d.sentry.SetData(key, value)
+	}
+}
+
+// Finish ends both spans, marking them as failed when err is non-nil.
+func (d *DualSpan) Finish(err error) {
+	if d.sentry != nil {
+		if err != nil {
+			d.sentry.Status = sentry.SpanStatusInternalError
+		} else {
+			d.sentry.Status = sentry.SpanStatusOK
+		}
+		d.sentry.Finish()
+	}
+	if d.otel != nil {
+		d.otel.End()
+	}
+}
+
+type dualSpanContextKey struct{}
+
+// SpanFromContext returns the innermost DualSpan started by
+// WithTracedTransaction or WithTracedSpan, or nil if none is present in ctx.
+func SpanFromContext(ctx context.Context) *DualSpan {
+	span, _ := ctx.Value(dualSpanContextKey{}).(*DualSpan)
+	return span
+}
+
+// WithTracedTransaction runs fn inside a Sentry transaction paired with an
+// OTel span started from tracer. Use this instead of SentryService.WithTransaction
+// when Instrumenter is "otel", so the OTel SDK stays the source of truth for
+// sampling while Bugsink still receives a matching transaction.
+func (s *SentryService) WithTracedTransaction(ctx context.Context, tracer trace.Tracer, name, operation string, fn func(context.Context, *DualSpan) error) error {
+	ctx, otelSpan := tracer.Start(ctx, name)
+	defer otelSpan.End()
+
+	sentrySpan := sentry.StartSpan(ctx, operation, sentry.WithTransactionName(name))
+	defer sentrySpan.Finish()
+
+	dual := &DualSpan{otel: otelSpan, sentry: sentrySpan}
+	ctx = context.WithValue(sentrySpan.Context(), dualSpanContextKey{}, dual)
+
+	err := fn(ctx, dual)
+	if err != nil {
+		otelSpan.RecordError(err)
+		sentrySpan.Status = sentry.SpanStatusInternalError
+		return err
+	}
+
+	sentrySpan.Status = sentry.SpanStatusOK
+	return nil
+}
+
+// WithTracedSpan runs fn inside a child span on both the OTel trace and the
+// Sentry transaction found in ctx.
+func (s *SentryService) WithTracedSpan(ctx context.Context, tracer trace.Tracer, operation, description string, fn func(context.Context, *DualSpan) error) error {
+	ctx, otelSpan := tracer.Start(ctx, description)
+	defer otelSpan.End()
+
+	sentrySpan := sentry.StartSpan(ctx, operation, sentry.WithDescription(description))
+	defer sentrySpan.Finish()
+
+	dual := &DualSpan{otel: otelSpan, sentry: sentrySpan}
+	ctx = context.WithValue(sentrySpan.Context(), dualSpanContextKey{}, dual)
+
+	err := fn(ctx, dual)
+	if err != nil {
+		otelSpan.RecordError(err)
+		sentrySpan.Status = sentry.SpanStatusInternalError
+		return err
+	}
+
+	sentrySpan.Status = sentry.SpanStatusOK
+	return nil
+}
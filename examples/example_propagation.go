@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// =============================================================================
+// DISTRIBUTED TRACE PROPAGATION
+// =============================================================================
+
+type traceContinuationContextKey struct{}
+
+// InjectTraceHeaders writes the W3C "sentry-trace" and "baggage" headers
+// for span onto h, so a downstream service can continue the same trace.
+// SentryRoundTripper calls this for every outbound request that carries a
+// parent span.
+func (s *SentryService) InjectTraceHeaders(span *sentry.Span, h http.Header) {
+	h.Set(sentry.SentryTraceHeader, span.ToSentryTrace())
+	if baggage := span.ToBaggage(); baggage != "" {
+		h.Set(sentry.SentryBaggageHeader, baggage)
+	}
+}
+
+// ContinueTraceFromRequest parses the "sentry-trace"/"baggage" headers on
+// an incoming request and returns a context that WithTransaction uses to
+// continue the caller's trace instead of starting a new one.
+func (s *SentryService) ContinueTraceFromRequest(ctx context.Context, r *http.Request) context.Context {
+	continuation := sentry.ContinueFromRequest(r)
+	return context.WithValue(ctx, traceContinuationContextKey{}, continuation)
+}
@@ -22,6 +22,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"regexp"
 	"runtime"
 	"strings"
 	"time"
@@ -42,10 +43,11 @@ func getEnv(key, defaultValue string) string {
 }
 
 var (
-	SentryDSN   = getEnv("SENTRY_DSN", "https://your-project-key@errors.observability.app.bauer-group.com/1")
-	Environment = getEnv("ENVIRONMENT", "development")
-	Release     = getEnv("APP_VERSION", "1.0.0")
-	ServerName  = getEnv("HOSTNAME", "")
+	SentryDSN    = getEnv("SENTRY_DSN", "https://your-project-key@errors.observability.app.bauer-group.com/1")
+	Environment  = getEnv("ENVIRONMENT", "development")
+	Release      = getEnv("APP_VERSION", "1.0.0")
+	ServerName   = getEnv("HOSTNAME", "")
+	Instrumenter = getEnv("SENTRY_INSTRUMENTER", "sentry") // "sentry" or "otel"
 )
 
 // =============================================================================
@@ -55,11 +57,66 @@ var (
 // SentryService provides a wrapper around the Sentry SDK
 type SentryService struct {
 	initialized bool
+
+	// Instrumenter selects which system owns tracing decisions. "sentry"
+	// (the default) uses TracesSampleRate/TracesSampler as configured
+	// below. "otel" delegates sampling to an upstream OpenTelemetry
+	// collector; see example_otel.go for the paired span helpers.
+	Instrumenter string
+
+	// IgnoreErrors and IgnoreTransactions are regex patterns matched
+	// against exception Type/Value and transaction names respectively.
+	// A match drops the event in beforeSendHandler. IgnoreErrors is also
+	// passed to sentry.ClientOptions.IgnoreErrors so the SDK can skip
+	// matching errors earlier; IgnoreTransactions is not passed on.
+	IgnoreErrors       []string
+	IgnoreTransactions []string
+
+	ignoreErrorsRe       []*regexp.Regexp
+	ignoreTransactionsRe []*regexp.Regexp
+
+	// ErrorFilter is consulted for every captured error in addition to
+	// the registered expected errors; returning true drops the event.
+	ErrorFilter func(error) bool
+
+	expectedErrors []error
 }
 
 // NewSentryService creates a new SentryService instance
 func NewSentryService() *SentryService {
-	return &SentryService{}
+	return &SentryService{Instrumenter: Instrumenter}
+}
+
+// RegisterExpectedError adds target to the allowlist of sentinel errors
+// checked via errors.Is inside beforeSendHandler. Use this instead of
+// editing the handler for every new expected error, e.g.:
+//
+//	sentryService.RegisterExpectedError(context.Canceled)
+//	sentryService.RegisterExpectedError(sql.ErrNoRows)
+func (s *SentryService) RegisterExpectedError(target error) {
+	s.expectedErrors = append(s.expectedErrors, target)
+}
+
+// compileIgnorePatterns compiles IgnoreErrors/IgnoreTransactions once so
+// beforeSendHandler doesn't re-parse regexes per event.
+func (s *SentryService) compileIgnorePatterns() error {
+	for _, pattern := range s.IgnoreErrors {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid IgnoreErrors pattern %q: %w", pattern, err)
+		}
+		s.ignoreErrorsRe = append(s.ignoreErrorsRe, re)
+	}
+
+	for _, pattern := range s.IgnoreTransactions {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid IgnoreTransactions pattern %q: %w", pattern, err)
+		}
+		s.ignoreTransactionsRe = append(s.ignoreTransactionsRe, re)
+	}
+
+	return nil
 }
 
 // Init initializes the Sentry SDK
@@ -69,13 +126,15 @@ func (s *SentryService) Init() error {
 		return nil
 	}
 
-	// Determine sample rate based on environment
-	tracesSampleRate := 1.0
-	if Environment == "production" {
-		tracesSampleRate = 0.1
+	if s.Instrumenter == "" {
+		s.Instrumenter = "sentry"
+	}
+
+	if err := s.compileIgnorePatterns(); err != nil {
+		return err
 	}
 
-	err := sentry.Init(sentry.ClientOptions{
+	options := sentry.ClientOptions{
 		Dsn:              SentryDSN,
 		Environment:      Environment,
 		Release:          fmt.Sprintf("my-app@%s", Release),
@@ -83,19 +142,35 @@ func (s *SentryService) Init() error {
 		Debug:            Environment == "development",
 		AttachStacktrace: true,
 
-		// Performance Monitoring
-		TracesSampleRate: tracesSampleRate,
-		ProfilesSampleRate: 0.1,
-
 		// Error Sampling
-		SampleRate: 1.0,
+		SampleRate:   1.0,
+		IgnoreErrors: s.IgnoreErrors,
 
 		// Before Send Hook
-		BeforeSend: beforeSendHandler,
+		BeforeSend: s.beforeSendHandler,
 
 		// Before Breadcrumb Hook
 		BeforeBreadcrumb: beforeBreadcrumbHandler,
-	})
+	}
+
+	if s.Instrumenter == "otel" {
+		// sentry-go has no client-level "otel" instrumenter switch (that's
+		// a Python/JS SDK concept); OTel mode is implemented entirely by
+		// the DualSpan/WithTracedTransaction bridge in example_otel.go, so
+		// there's nothing to set on ClientOptions here. Sampling is owned
+		// by the OTel collector/SDK and TracesSampleRate/TracesSampler are
+		// simply left unset in this branch.
+	} else {
+		// Determine sample rate based on environment
+		tracesSampleRate := 1.0
+		if Environment == "production" {
+			tracesSampleRate = 0.1
+		}
+		options.TracesSampleRate = tracesSampleRate
+		options.ProfilesSampleRate = 0.1
+	}
+
+	err := sentry.Init(options)
 
 	if err != nil {
 		return fmt.Errorf("sentry initialization failed: %w", err)
@@ -115,7 +190,7 @@ func (s *SentryService) Init() error {
 }
 
 // beforeSendHandler processes events before sending
-func beforeSendHandler(event *sentry.Event, hint *sentry.EventHint) *sentry.Event {
+func (s *SentryService) beforeSendHandler(event *sentry.Event, hint *sentry.EventHint) *sentry.Event {
 	// Sanitize sensitive headers
 	if event.Request != nil && event.Request.Headers != nil {
 		sensitiveHeaders := []string{"Authorization", "Cookie", "X-API-Key"}
@@ -132,6 +207,34 @@ func beforeSendHandler(event *sentry.Event, hint *sentry.EventHint) *sentry.Even
 		if errors.As(hint.OriginalException, &expectedErr) {
 			return nil // Don't send this event
 		}
+
+		for _, target := range s.expectedErrors {
+			if errors.Is(hint.OriginalException, target) {
+				return nil
+			}
+		}
+
+		if s.ErrorFilter != nil && s.ErrorFilter(hint.OriginalException) {
+			return nil
+		}
+	}
+
+	// Drop events whose exception type/value matches an IgnoreErrors pattern
+	for _, exception := range event.Exception {
+		for _, re := range s.ignoreErrorsRe {
+			if re.MatchString(exception.Type) || re.MatchString(exception.Value) {
+				return nil
+			}
+		}
+	}
+
+	// Drop events belonging to an ignored transaction
+	if event.Transaction != "" {
+		for _, re := range s.ignoreTransactionsRe {
+			if re.MatchString(event.Transaction) {
+				return nil
+			}
+		}
 	}
 
 	return event
@@ -247,9 +350,16 @@ func (s *SentryService) CaptureMessageWithContext(message string, level sentry.L
 	return eventID
 }
 
-// WithTransaction executes a function within a transaction
+// WithTransaction executes a function within a transaction. If ctx carries
+// a trace continuation from ContinueTraceFromRequest, the transaction joins
+// that trace instead of starting a new one.
 func (s *SentryService) WithTransaction(ctx context.Context, name, operation string, fn func(context.Context, *sentry.Span) error) error {
-	span := sentry.StartSpan(ctx, operation, sentry.WithTransactionName(name))
+	spanOptions := []sentry.SpanOption{sentry.WithTransactionName(name)}
+	if continuation, ok := ctx.Value(traceContinuationContextKey{}).(sentry.SpanOption); ok {
+		spanOptions = append(spanOptions, continuation)
+	}
+
+	span := sentry.StartSpan(ctx, operation, spanOptions...)
 	defer span.Finish()
 
 	err := fn(span.Context(), span)
@@ -301,12 +411,16 @@ func (e *ExpectedBusinessError) Error() string {
 
 // ExampleService demonstrates Sentry integration patterns
 type ExampleService struct {
-	sentry *SentryService
+	sentry     *SentryService
+	httpClient *http.Client
 }
 
 // NewExampleService creates a new ExampleService
 func NewExampleService(sentry *SentryService) *ExampleService {
-	return &ExampleService{sentry: sentry}
+	return &ExampleService{
+		sentry:     sentry,
+		httpClient: sentry.NewInstrumentedHTTPClient(map[string]string{"client": "example-service"}),
+	}
 }
 
 // FetchData fetches data with error tracking
@@ -332,7 +446,18 @@ func (s *ExampleService) ProcessBatch(ctx context.Context, items []string) (int,
 	err := s.sentry.WithTransaction(ctx, "process_batch", "task", func(ctx context.Context, span *sentry.Span) error {
 		for _, item := range items {
 			err := s.sentry.WithSpan(ctx, "task.item", fmt.Sprintf("process_%s", item), func(ctx context.Context) error {
-				time.Sleep(50 * time.Millisecond) // Simulate work
+				// Downstream call is recorded as a child span of "task.item"
+				// because the SentryRoundTripper picks up the span from ctx.
+				request, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/status", nil)
+				if err != nil {
+					return err
+				}
+				response, err := s.httpClient.Do(request)
+				if err != nil {
+					return err
+				}
+				response.Body.Close()
+
 				processed++
 				return nil
 			})
@@ -403,8 +528,15 @@ func CreateHTTPHandler(sentryService *SentryService) http.Handler {
 		w.Write([]byte(`{"status": "message sent"}`))
 	})
 
+	// Continue any trace started by an upstream caller before the request
+	// reaches the routes above, so a subsequent WithTransaction joins it.
+	tracedMux := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := sentryService.ContinueTraceFromRequest(r.Context(), r)
+		mux.ServeHTTP(w, r.WithContext(ctx))
+	})
+
 	// Wrap with Sentry handler
-	return sentryHandler.Handle(mux)
+	return sentryHandler.Handle(tracedMux)
 }
 
 // =============================================================================